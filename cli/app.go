@@ -0,0 +1,201 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cli provides a small framework for building command-line applications out of an App, a tree of Commands
+// and typed Flags (see the sibling "flag" package).
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/palantir/pkg/cli/flag"
+)
+
+// errHalt is the sentinel error returned by Halt.
+var errHalt = errors.New("cli: halt")
+
+// Halt returns an error that a Before hook can return to stop Run immediately with exit status 0, without running
+// the resolved Action or (if one would otherwise run next) the previously configured Before hook. It is intended for
+// Before hooks that fully handle the invocation themselves, such as one that prints generated documentation and
+// should exit cleanly rather than continue on to the app's Action.
+func Halt() error {
+	return errHalt
+}
+
+// BeforeFunc is invoked (if set) after flags are parsed but before the resolved Action is run.
+type BeforeFunc func(ctx Context) error
+
+// AfterFunc is invoked (if set) after the resolved Action returns, regardless of whether it returned an error.
+type AfterFunc func(ctx Context) error
+
+// ErrorHandlerFunc is invoked (if set) whenever Before, the resolved Action, or After return a non-nil error. It is
+// responsible for reporting the error and determining the process exit code.
+type ErrorHandlerFunc func(ctx Context, err error) int
+
+// Option configures an App. Options are applied, in order, by NewApp.
+type Option func(*App)
+
+// App represents a command-line application.
+type App struct {
+	Name         string
+	Usage        string
+	Flags        []flag.Flag
+	Subcommands  []Command
+	Action       ActionFunc
+	Before       BeforeFunc
+	After        AfterFunc
+	ErrorHandler ErrorHandlerFunc
+	Stdout       io.Writer
+	Stderr       io.Writer
+	// EnableShellCompletion, if true, causes Run to recognize the shell-completion sentinel arguments produced by
+	// the scripts that GenerateCompletion generates (for example "--generate-bash-completion") and respond with
+	// completion candidates instead of resolving and running a Command. See GenerateCompletion.
+	EnableShellCompletion bool
+}
+
+// NewApp returns a new App with Stdout and Stderr set to os.Stdout and os.Stderr and applies the provided Options to
+// it (in order).
+func NewApp(options ...Option) *App {
+	app := &App{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	for _, option := range options {
+		option(app)
+	}
+	return app
+}
+
+// Run parses args (where args[0] is the name of the running program, matching os.Args) and executes the resolved
+// Command or App Action, returning the process exit code.
+func (a *App) Run(args []string) int {
+	if a.EnableShellCompletion {
+		if words, ok := completionRequestWords(args); ok {
+			for _, candidate := range completionCandidates(a, words) {
+				fmt.Fprintln(a.Stdout, candidate)
+			}
+			return 0
+		}
+	}
+
+	command, rest := a.resolveCommand(args)
+
+	flags := a.Flags
+	action := a.Action
+	if command != nil {
+		flags = command.Flags
+		action = command.Action
+	}
+
+	values, err := parseFlags(flags, rest)
+	if err != nil {
+		fmt.Fprintln(a.Stderr, err)
+		return 1
+	}
+	ctx := newContext(a, command, values)
+
+	if a.Before != nil {
+		if err := a.Before(ctx); err != nil {
+			if err == errHalt {
+				return 0
+			}
+			return a.handleErr(ctx, err)
+		}
+	}
+
+	if action != nil {
+		err = action(ctx)
+	}
+
+	if a.After != nil {
+		if afterErr := a.After(ctx); err == nil {
+			err = afterErr
+		}
+	}
+
+	if err != nil {
+		return a.handleErr(ctx, err)
+	}
+	return 0
+}
+
+func (a *App) handleErr(ctx Context, err error) int {
+	if a.ErrorHandler != nil {
+		return a.ErrorHandler(ctx, err)
+	}
+	if msg := err.Error(); msg != "" {
+		fmt.Fprintln(a.Stderr, msg)
+	}
+	return 1
+}
+
+// resolveCommand walks args[1:] against a's tree of Subcommands, descending one level for each consecutive argument
+// that names a Subcommand of the Command resolved so far. It returns the most deeply resolved Command (or nil if
+// args[1] does not name a top-level Subcommand) along with the remaining arguments, which are parsed as that
+// Command's (or, if nil, the App's) flags and parameters.
+func (a *App) resolveCommand(args []string) (*Command, []string) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	var command *Command
+	subcommands := a.Subcommands
+	rest := args[1:]
+	for len(rest) > 0 {
+		var next *Command
+		for i := range subcommands {
+			if subcommands[i].Name == rest[0] {
+				next = &subcommands[i]
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		command = next
+		subcommands = command.Subcommands
+		rest = rest[1:]
+	}
+	return command, rest
+}
+
+// resolveFlagArg matches a "--name" or "--name=value" argument against flags, returning the matched Flag along with
+// its value (if any). A flag name may itself contain "=", so matching is not a naive split on the first "=": among
+// all flags whose name is either an exact match for the trimmed argument or a prefix of it followed by "=", the one
+// with the longest name wins, and only the "=" immediately after that name (if any) is treated as the separator.
+func resolveFlagArg(flags []flag.Flag, arg string) (f flag.Flag, value string, hasValue bool) {
+	trimmed := strings.TrimPrefix(arg, "--")
+	bestLen := -1
+	for _, cand := range flags {
+		name := cand.MainName()
+		switch {
+		case trimmed == name:
+			if len(name) > bestLen {
+				f, value, hasValue, bestLen = cand, "", false, len(name)
+			}
+		case strings.HasPrefix(trimmed, name+"="):
+			if len(name) > bestLen {
+				f, value, hasValue, bestLen = cand, trimmed[len(name)+1:], true, len(name)
+			}
+		}
+	}
+	return f, value, hasValue
+}
+
+func isFlagArg(arg string) bool {
+	return strings.HasPrefix(arg, "--")
+}
@@ -0,0 +1,78 @@
+//go:build !no_docs
+// +build !no_docs
+
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cfgdocs provides an opt-in cli.Option, analogous to cfgcli.Handler, that adds hidden flags for printing
+// generated documentation for a cli.App.
+package cfgdocs
+
+import (
+	"github.com/palantir/pkg/cli"
+	"github.com/palantir/pkg/cli/docs"
+	"github.com/palantir/pkg/cli/flag"
+)
+
+const (
+	docsMarkdownFlag = "docs-markdown"
+	docsManFlag      = "docs-man"
+)
+
+// Handler returns a cli.Option that configures a cli.App with hidden "--docs-markdown" and "--docs-man" global
+// flags. The application is configured with a "Before" hook that, if either flag was provided, prints the
+// corresponding generated documentation to Stdout and returns cli.Halt(), causing Run to stop with exit status 0
+// before running the previously configured "Before" hook or the app's Action.
+func Handler() cli.Option {
+	return func(app *cli.App) {
+		// store app.Before previously set on App
+		before := app.Before
+		// add a Before hook that prints and exits if either documentation flag was provided
+		app.Before = func(ctx cli.Context) error {
+			switch {
+			case ctx.Bool(docsMarkdownFlag):
+				return printDocs(app, ctx, docs.ToMarkdown)
+			case ctx.Bool(docsManFlag):
+				return printDocs(app, ctx, docs.ToMan)
+			}
+
+			// if app.Before was previously defined, use it
+			if before != nil {
+				return before(ctx)
+			}
+			return nil
+		}
+		app.Flags = append(app.Flags,
+			flag.BoolFlag{
+				Name:   docsMarkdownFlag,
+				Usage:  "Print Markdown documentation for this application and exit",
+				Hidden: true,
+			},
+			flag.BoolFlag{
+				Name:   docsManFlag,
+				Usage:  "Print man-page documentation for this application and exit",
+				Hidden: true,
+			},
+		)
+	}
+}
+
+func printDocs(app *cli.App, ctx cli.Context, render func(*cli.App) (string, error)) error {
+	out, err := render(app)
+	if err != nil {
+		return err
+	}
+	ctx.Printf("%s", out)
+	return cli.Halt()
+}
@@ -0,0 +1,26 @@
+//go:build no_docs
+// +build no_docs
+
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfgdocs
+
+import "github.com/palantir/pkg/cli"
+
+// Handler returns a no-op cli.Option. This binary was built with the "no_docs" tag, which strips documentation
+// generation (and the flags that trigger it) to reduce binary size.
+func Handler() cli.Option {
+	return func(app *cli.App) {}
+}
@@ -0,0 +1,71 @@
+//go:build !no_docs
+// +build !no_docs
+
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfgdocs_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cli"
+	"github.com/palantir/pkg/cli/cfgdocs"
+)
+
+var errActionRan = errors.New("action ran")
+
+func testApp() *cli.App {
+	app := cli.NewApp(cfgdocs.Handler())
+	app.Name = "myapp"
+	app.Usage = "does application things"
+	app.Action = func(ctx cli.Context) error {
+		return errActionRan
+	}
+	return app
+}
+
+func TestDocsMarkdownFlagPrintsAndHalts(t *testing.T) {
+	app := testApp()
+	stdout := &bytes.Buffer{}
+	app.Stdout = stdout
+
+	exitStatus := app.Run([]string{"myapp", "--docs-markdown"})
+	assert.Equal(t, 0, exitStatus)
+	assert.Contains(t, stdout.String(), "myapp")
+	assert.Contains(t, stdout.String(), "does application things")
+}
+
+func TestDocsManFlagPrintsAndHalts(t *testing.T) {
+	app := testApp()
+	stdout := &bytes.Buffer{}
+	app.Stdout = stdout
+
+	exitStatus := app.Run([]string{"myapp", "--docs-man"})
+	assert.Equal(t, 0, exitStatus)
+	assert.Contains(t, stdout.String(), "MYAPP")
+}
+
+func TestWithoutDocsFlagsActionRuns(t *testing.T) {
+	app := testApp()
+	app.Stdout = &bytes.Buffer{}
+	app.Stderr = &bytes.Buffer{}
+
+	exitStatus := app.Run([]string{"myapp"})
+	assert.Equal(t, 1, exitStatus, "app's Action should have run (and returned an error) rather than being skipped")
+}
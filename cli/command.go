@@ -0,0 +1,34 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import "github.com/palantir/pkg/cli/flag"
+
+// ActionFunc is the function that is invoked to run an App or Command.
+type ActionFunc func(ctx Context) error
+
+// Command represents a named subcommand of an App.
+type Command struct {
+	Name        string
+	Usage       string
+	Flags       []flag.Flag
+	Subcommands []Command
+	Action      ActionFunc
+	// BashComplete, if set, is invoked while generating shell completion candidates for an invocation resolved to
+	// this Command. It is given the opportunity to print additional dynamic completion candidates (one per line,
+	// via ctx.Printf) beyond the Command's flags and Subcommands - for example, candidates produced by matching
+	// filenames against a matcher.Matcher. See App.EnableShellCompletion and GenerateCompletion.
+	BashComplete func(ctx Context)
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Shell identifies a shell supported by GenerateCompletion.
+type Shell string
+
+const (
+	ShellBash Shell = "bash"
+	ShellZsh  Shell = "zsh"
+	ShellFish Shell = "fish"
+)
+
+var completionShells = []Shell{ShellBash, ShellZsh, ShellFish}
+
+// completionSentinel returns the trailing argument that signals a shell-completion request for shell (for example
+// "--generate-bash-completion"). The completion scripts generated by GenerateCompletion invoke the application with
+// the word currently being completed as the last ordinary argument, followed by this sentinel.
+func completionSentinel(shell Shell) string {
+	return fmt.Sprintf("--generate-%s-completion", shell)
+}
+
+// completionRequestWords reports whether args (as passed to App.Run) ends in a completion sentinel, and if so
+// returns the words that precede it (excluding the program name). The last element of words is the partial word
+// currently being completed (which may be "").
+func completionRequestWords(args []string) (words []string, ok bool) {
+	if len(args) < 1 {
+		return nil, false
+	}
+	last := args[len(args)-1]
+	for _, shell := range completionShells {
+		if last == completionSentinel(shell) {
+			return args[1 : len(args)-1], true
+		}
+	}
+	return nil, false
+}
+
+// completionCandidates resolves words against app's subcommand tree (like App.Run, except that "--" flag arguments
+// are skipped rather than ending the walk) and returns the sorted list of candidates for completing the final element of words: subcommand names and flag long names at the
+// resolved position, filtered to those with the current word as a prefix, together with any candidates contributed
+// by the resolved Command's BashComplete hook.
+func completionCandidates(app *App, words []string) []string {
+	flags := app.Flags
+	subcommands := app.Subcommands
+	var command *Command
+
+	for i := 0; i < len(words)-1; i++ {
+		token := words[i]
+		if isFlagArg(token) {
+			continue
+		}
+		var next *Command
+		for idx := range subcommands {
+			if subcommands[idx].Name == token {
+				next = &subcommands[idx]
+				break
+			}
+		}
+		if next == nil {
+			// token does not resolve to a subcommand (for example, a parameter value): nothing further to resolve
+			break
+		}
+		command = next
+		flags = command.Flags
+		subcommands = command.Subcommands
+	}
+
+	current := ""
+	if len(words) > 0 {
+		current = words[len(words)-1]
+	}
+
+	var candidates []string
+	if isFlagArg(current) {
+		for _, f := range flags {
+			candidates = append(candidates, "--"+f.MainName())
+		}
+	} else {
+		for _, sub := range subcommands {
+			candidates = append(candidates, sub.Name)
+		}
+	}
+
+	out := &strings.Builder{}
+	if command != nil && command.BashComplete != nil {
+		command.BashComplete(newContextWithStdout(app, command, map[string]interface{}{}, out))
+	}
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line != "" {
+			candidates = append(candidates, line)
+		}
+	}
+
+	var filtered []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, current) {
+			filtered = append(filtered, c)
+		}
+	}
+	sort.Strings(filtered)
+	return filtered
+}
+
+// GenerateCompletion writes a shell completion script for app to w. The generated script, once installed per the
+// target shell's conventions, re-invokes app's binary with the word currently being completed followed by a
+// sentinel flag (for example "--generate-bash-completion"); App.Run recognizes that sentinel (when
+// app.EnableShellCompletion is true) and prints completion candidates instead of running app's Action.
+func GenerateCompletion(app *App, shell Shell, w io.Writer) error {
+	switch shell {
+	case ShellBash:
+		return writeBashCompletion(app, w)
+	case ShellZsh:
+		return writeZshCompletion(app, w)
+	case ShellFish:
+		return writeFishCompletion(app, w)
+	default:
+		return fmt.Errorf("unsupported shell for completion: %v", shell)
+	}
+}
+
+func writeBashCompletion(app *App, w io.Writer) error {
+	_, err := fmt.Fprintf(w, `_%[1]s_complete() {
+	local words=("${COMP_WORDS[@]:1:$COMP_CWORD}")
+	COMPREPLY=($(%[1]s "${words[@]}" %[2]s))
+}
+complete -F _%[1]s_complete %[1]s
+`, app.Name, completionSentinel(ShellBash))
+	return err
+}
+
+func writeZshCompletion(app *App, w io.Writer) error {
+	_, err := fmt.Fprintf(w, `#compdef %[1]s
+_%[1]s() {
+	local words=("${words[@]:1}")
+	reply=(${(f)"$(%[1]s "${words[@]}" %[2]s)"})
+}
+compdef _%[1]s %[1]s
+`, app.Name, completionSentinel(ShellZsh))
+	return err
+}
+
+func writeFishCompletion(app *App, w io.Writer) error {
+	_, err := fmt.Fprintf(w, `function __%[1]s_complete
+	set -l words (commandline -opc)
+	%[1]s $words[2..-1] (commandline -ct) %[2]s
+end
+complete -c %[1]s -f -a '(__%[1]s_complete)'
+`, app.Name, completionSentinel(ShellFish))
+	return err
+}
@@ -0,0 +1,106 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cli"
+	"github.com/palantir/pkg/cli/flag"
+)
+
+func completionTestApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = "myapp"
+	app.EnableShellCompletion = true
+	app.Flags = []flag.Flag{
+		flag.StringFlag{Name: "name"},
+	}
+	app.Subcommands = []cli.Command{
+		{
+			Name: "build",
+			Flags: []flag.Flag{
+				flag.BoolFlag{Name: "verbose"},
+			},
+			BashComplete: func(ctx cli.Context) {
+				ctx.Printf("fast\nslow\n")
+			},
+		},
+		{Name: "test"},
+	}
+	return app
+}
+
+func runCompletion(t *testing.T, args []string) []string {
+	app := completionTestApp()
+	stdout := &bytes.Buffer{}
+	app.Stdout = stdout
+	exitStatus := app.Run(args)
+	require.Equal(t, 0, exitStatus)
+	var lines []string
+	for _, line := range bytes.Split(stdout.Bytes(), []byte("\n")) {
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+	}
+	return lines
+}
+
+func TestCompletionAtAppRoot(t *testing.T) {
+	lines := runCompletion(t, []string{"./myapp", "--generate-bash-completion"})
+	assert.Equal(t, []string{"build", "test"}, lines)
+}
+
+func TestCompletionInsideSubcommand(t *testing.T) {
+	lines := runCompletion(t, []string{"./myapp", "build", "", "--generate-bash-completion"})
+	assert.Equal(t, []string{"fast", "slow"}, lines)
+}
+
+func TestCompletionAfterPartialFlag(t *testing.T) {
+	lines := runCompletion(t, []string{"./myapp", "--na", "--generate-bash-completion"})
+	assert.Equal(t, []string{"--name"}, lines)
+}
+
+func TestCompletionDisabledWithoutEnableShellCompletion(t *testing.T) {
+	app := completionTestApp()
+	app.EnableShellCompletion = false
+	app.Subcommands = nil
+	app.Action = func(ctx cli.Context) error { return nil }
+	stdout := &bytes.Buffer{}
+	app.Stdout = stdout
+	stderr := &bytes.Buffer{}
+	app.Stderr = stderr
+
+	// with completion disabled, the sentinel is just an ordinary (unrecognized) flag
+	exitStatus := app.Run([]string{"./myapp", "--generate-bash-completion"})
+	assert.Equal(t, 1, exitStatus)
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "Unknown flag --generate-bash-completion")
+}
+
+func TestGenerateCompletion(t *testing.T) {
+	app := completionTestApp()
+
+	for _, shell := range []cli.Shell{cli.ShellBash, cli.ShellZsh, cli.ShellFish} {
+		buf := &bytes.Buffer{}
+		require.NoError(t, cli.GenerateCompletion(app, shell, buf))
+		assert.Contains(t, buf.String(), "myapp")
+		assert.Contains(t, buf.String(), "--generate-"+string(shell)+"-completion")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package completioncli provides an opt-in cli.Option, analogous to cfgcli.Handler, that adds a "completion"
+// subcommand to a cli.App for generating shell completion scripts.
+package completioncli
+
+import "github.com/palantir/pkg/cli"
+
+// Handler returns a cli.Option that sets app.EnableShellCompletion and adds a "completion" subcommand with one
+// nested subcommand per shell supported by cli.GenerateCompletion ("bash", "zsh", and "fish"), each of which prints
+// the corresponding completion script to Stdout. For example, "myapp completion bash" prints a bash completion
+// script for myapp.
+func Handler() cli.Option {
+	return func(app *cli.App) {
+		app.EnableShellCompletion = true
+		app.Subcommands = append(app.Subcommands, cli.Command{
+			Name:  "completion",
+			Usage: "Print a shell completion script for this application",
+			Subcommands: []cli.Command{
+				shellCommand(cli.ShellBash),
+				shellCommand(cli.ShellZsh),
+				shellCommand(cli.ShellFish),
+			},
+		})
+	}
+}
+
+func shellCommand(shell cli.Shell) cli.Command {
+	return cli.Command{
+		Name:  string(shell),
+		Usage: "Print a " + string(shell) + " completion script for this application",
+		Action: func(ctx cli.Context) error {
+			return cli.GenerateCompletion(ctx.App(), shell, ctx.App().Stdout)
+		},
+	}
+}
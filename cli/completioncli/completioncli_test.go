@@ -0,0 +1,38 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completioncli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cli"
+	"github.com/palantir/pkg/cli/completioncli"
+)
+
+func TestCompletionSubcommands(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		app := cli.NewApp(completioncli.Handler())
+		app.Name = "myapp"
+		stdout := &bytes.Buffer{}
+		app.Stdout = stdout
+
+		exitStatus := app.Run([]string{"myapp", "completion", shell})
+		assert.Equal(t, 0, exitStatus, "completion %v: %v", shell, stdout.String())
+		assert.Contains(t, stdout.String(), "myapp")
+	}
+}
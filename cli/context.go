@@ -0,0 +1,139 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/palantir/pkg/cli/flag"
+)
+
+// Context provides access to the flag values parsed for the current App or Command invocation.
+type Context struct {
+	app     *App
+	command *Command
+	values  map[string]interface{}
+	// stdout overrides app.Stdout as the destination for Printf if non-nil. This is used internally to capture
+	// output (for example, candidates written by a Command's BashComplete hook) without writing it directly to the
+	// App's real Stdout.
+	stdout io.Writer
+}
+
+func newContext(app *App, command *Command, values map[string]interface{}) Context {
+	return Context{
+		app:     app,
+		command: command,
+		values:  values,
+	}
+}
+
+// newContextWithStdout behaves like newContext, but Printf writes to stdout instead of app.Stdout.
+func newContextWithStdout(app *App, command *Command, values map[string]interface{}, stdout io.Writer) Context {
+	return Context{
+		app:     app,
+		command: command,
+		values:  values,
+		stdout:  stdout,
+	}
+}
+
+// Has returns true if a value was recorded for the provided flag name (either a default or a user-supplied value).
+func (c Context) Has(name string) bool {
+	_, ok := c.values[name]
+	return ok
+}
+
+// String returns the string value for the named flag or parameter. Returns the empty string if the flag does not
+// exist or is not a string-valued flag.
+func (c Context) String(name string) string {
+	v, _ := c.values[name].(string)
+	return v
+}
+
+// Bool returns the boolean value for the named flag. Returns false if the flag does not exist or is not a
+// bool-valued flag.
+func (c Context) Bool(name string) bool {
+	v, _ := c.values[name].(bool)
+	return v
+}
+
+// Slice returns the string slice value for the named flag. Returns nil if the flag does not exist or is not a
+// string-slice-valued flag.
+func (c Context) Slice(name string) []string {
+	v, _ := c.values[name].([]string)
+	return v
+}
+
+// IntSlice returns the int slice value for the named flag. Returns nil if the flag does not exist or is not an
+// int-slice-valued flag.
+func (c Context) IntSlice(name string) []int {
+	v, _ := c.values[name].([]int)
+	return v
+}
+
+// Float64Slice returns the float64 slice value for the named flag. Returns nil if the flag does not exist or is not
+// a float64-slice-valued flag.
+func (c Context) Float64Slice(name string) []float64 {
+	v, _ := c.values[name].([]float64)
+	return v
+}
+
+// DurationSlice returns the time.Duration slice value for the named flag. Returns nil if the flag does not exist or
+// is not a duration-slice-valued flag.
+func (c Context) DurationSlice(name string) []time.Duration {
+	v, _ := c.values[name].([]time.Duration)
+	return v
+}
+
+// StringMap returns the map value for the named flag. Returns nil if the flag does not exist or is not a
+// MapFlag-valued flag.
+func (c Context) StringMap(name string) map[string]string {
+	v, _ := c.values[name].(map[string]string)
+	return v
+}
+
+// KeyValueSlice returns the ordered flag.KeyValue slice value for the named flag. Returns nil if the flag does not
+// exist or is not a KeyValueSlice-valued flag.
+func (c Context) KeyValueSlice(name string) []flag.KeyValue {
+	v, _ := c.values[name].([]flag.KeyValue)
+	return v
+}
+
+// Errorf writes the formatted message to the App's Stderr writer.
+func (c Context) Errorf(format string, args ...interface{}) {
+	fmt.Fprintf(c.app.Stderr, format, args...)
+}
+
+// Printf writes the formatted message to the App's Stdout writer.
+func (c Context) Printf(format string, args ...interface{}) {
+	w := c.stdout
+	if w == nil {
+		w = c.app.Stdout
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
+// App returns the App associated with this Context.
+func (c Context) App() *App {
+	return c.app
+}
+
+// Command returns the Command associated with this Context, or nil if the Context was created for the App's own
+// Action rather than for a subcommand.
+func (c Context) Command() *Command {
+	return c.command
+}
@@ -0,0 +1,137 @@
+//go:build !no_docs
+// +build !no_docs
+
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docs generates Markdown and man-page reference documentation for a cli.App by walking its flags and
+// subcommands. Because the generated templates are unnecessary in (and bloat) production binaries, the real
+// implementation in this file is omitted from builds tagged "no_docs" in favor of the stub in docs_stub.go.
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/palantir/pkg/cli"
+	"github.com/palantir/pkg/cli/flag"
+)
+
+// ToMarkdown renders a Markdown reference document describing app: its name, usage, global flags, and every
+// subcommand (recursively), including each subcommand's own flags.
+func ToMarkdown(app *cli.App) (string, error) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# %s\n\n", app.Name)
+	if app.Usage != "" {
+		fmt.Fprintf(buf, "%s\n\n", app.Usage)
+	}
+	writeMarkdownFlags(buf, app.Flags)
+	for _, cmd := range app.Subcommands {
+		writeMarkdownCommand(buf, app.Name, cmd, 2)
+	}
+	return buf.String(), nil
+}
+
+// ToMan renders a groff man page describing app, structured the same way as ToMarkdown: a NAME/flags section for
+// app itself followed by one section per subcommand (recursively).
+func ToMan(app *cli.App) (string, error) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, ".TH %s 1\n", strings.ToUpper(app.Name))
+	fmt.Fprintf(buf, ".SH NAME\n%s", app.Name)
+	if app.Usage != "" {
+		fmt.Fprintf(buf, " \\- %s", app.Usage)
+	}
+	fmt.Fprintln(buf)
+	writeManFlags(buf, app.Flags)
+	for _, cmd := range app.Subcommands {
+		writeManCommand(buf, app.Name, cmd)
+	}
+	return buf.String(), nil
+}
+
+func writeMarkdownCommand(buf *bytes.Buffer, parentName string, cmd cli.Command, level int) {
+	fullName := parentName + " " + cmd.Name
+	fmt.Fprintf(buf, "%s %s\n\n", strings.Repeat("#", level), fullName)
+	if cmd.Usage != "" {
+		fmt.Fprintf(buf, "%s\n\n", cmd.Usage)
+	}
+	writeMarkdownFlags(buf, cmd.Flags)
+	for _, sub := range cmd.Subcommands {
+		writeMarkdownCommand(buf, fullName, sub, level+1)
+	}
+}
+
+func writeMarkdownFlags(buf *bytes.Buffer, flags []flag.Flag) {
+	visible := visibleFlags(flags)
+	if len(visible) == 0 {
+		return
+	}
+	fmt.Fprintln(buf, "| Flag | Usage |")
+	fmt.Fprintln(buf, "| --- | --- |")
+	for _, f := range visible {
+		fmt.Fprintf(buf, "| `--%s` | %s |\n", f.MainName(), flagUsage(f))
+	}
+	fmt.Fprintln(buf)
+}
+
+func writeManCommand(buf *bytes.Buffer, parentName string, cmd cli.Command) {
+	fullName := parentName + " " + cmd.Name
+	fmt.Fprintf(buf, ".SH %s\n", strings.ToUpper(fullName))
+	if cmd.Usage != "" {
+		fmt.Fprintf(buf, "%s\n", cmd.Usage)
+	}
+	writeManFlags(buf, cmd.Flags)
+	for _, sub := range cmd.Subcommands {
+		writeManCommand(buf, fullName, sub)
+	}
+}
+
+func writeManFlags(buf *bytes.Buffer, flags []flag.Flag) {
+	for _, f := range visibleFlags(flags) {
+		fmt.Fprintf(buf, ".TP\n\\fB--%s\\fR\n%s\n", f.MainName(), flagUsage(f))
+	}
+}
+
+// visibleFlags returns the subset of flags that do not have a "Hidden" field set to true.
+func visibleFlags(flags []flag.Flag) []flag.Flag {
+	var visible []flag.Flag
+	for _, f := range flags {
+		if isHidden(f) {
+			continue
+		}
+		visible = append(visible, f)
+	}
+	return visible
+}
+
+// flagUsage and isHidden read the conventional "Usage" and "Hidden" fields of a flag type (every flag type in the flag
+// package declares "Usage"; a type without a "Hidden" field is never hidden). Reflection is used here (rather than requiring every flag type to implement an additional interface) so
+// that new flag types automatically render correctly without changes to this package.
+func flagUsage(f flag.Flag) string {
+	field := reflect.ValueOf(f).FieldByName("Usage")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+	return field.String()
+}
+
+func isHidden(f flag.Flag) bool {
+	field := reflect.ValueOf(f).FieldByName("Hidden")
+	if !field.IsValid() || field.Kind() != reflect.Bool {
+		return false
+	}
+	return field.Bool()
+}
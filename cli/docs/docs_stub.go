@@ -0,0 +1,39 @@
+//go:build no_docs
+// +build no_docs
+
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import (
+	"errors"
+
+	"github.com/palantir/pkg/cli"
+)
+
+// errDocsDisabled is returned by ToMarkdown and ToMan in builds tagged "no_docs".
+var errDocsDisabled = errors.New("documentation generation is disabled (built with no_docs)")
+
+// ToMarkdown is a stub: this binary was built with the "no_docs" tag, which strips documentation generation to
+// reduce binary size.
+func ToMarkdown(app *cli.App) (string, error) {
+	return "", errDocsDisabled
+}
+
+// ToMan is a stub: this binary was built with the "no_docs" tag, which strips documentation generation to reduce
+// binary size.
+func ToMan(app *cli.App) (string, error) {
+	return "", errDocsDisabled
+}
@@ -0,0 +1,92 @@
+//go:build !no_docs
+// +build !no_docs
+
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cli"
+	"github.com/palantir/pkg/cli/docs"
+	"github.com/palantir/pkg/cli/flag"
+)
+
+func testApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = "myapp"
+	app.Usage = "does application things"
+	app.Flags = []flag.Flag{
+		flag.StringFlag{
+			Name:  "config",
+			Usage: "Path to configuration file",
+		},
+		flag.BoolFlag{
+			Name:   "internal-only",
+			Usage:  "Not for public consumption",
+			Hidden: true,
+		},
+	}
+	app.Subcommands = []cli.Command{
+		{
+			Name:  "build",
+			Usage: "builds the project",
+			Flags: []flag.Flag{
+				flag.BoolFlag{
+					Name:  "verbose",
+					Usage: "Enable verbose output",
+				},
+			},
+		},
+	}
+	return app
+}
+
+func TestToMarkdown(t *testing.T) {
+	out, err := docs.ToMarkdown(testApp())
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "myapp")
+	assert.Contains(t, out, "does application things")
+	assert.Contains(t, out, "--config")
+	assert.Contains(t, out, "Path to configuration file")
+	assert.Contains(t, out, "build")
+	assert.Contains(t, out, "builds the project")
+	assert.Contains(t, out, "--verbose")
+	assert.Contains(t, out, "Enable verbose output")
+
+	assert.False(t, strings.Contains(out, "--internal-only"), "hidden flag should not appear in output")
+}
+
+func TestToMan(t *testing.T) {
+	out, err := docs.ToMan(testApp())
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "MYAPP")
+	assert.Contains(t, out, "does application things")
+	assert.Contains(t, out, "--config")
+	assert.Contains(t, out, "Path to configuration file")
+	assert.Contains(t, out, "BUILD")
+	assert.Contains(t, out, "builds the project")
+	assert.Contains(t, out, "--verbose")
+	assert.Contains(t, out, "Enable verbose output")
+
+	assert.False(t, strings.Contains(out, "--internal-only"), "hidden flag should not appear in output")
+}
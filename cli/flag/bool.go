@@ -0,0 +1,30 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flag
+
+// BoolFlag is a Flag that accepts a boolean value. A BoolFlag that is provided on the command line without an
+// explicit value (for example, "--verbose" rather than "--verbose=true") is treated as true.
+//
+// A Hidden BoolFlag is still parsed normally, but is omitted from generated usage and documentation output.
+type BoolFlag struct {
+	Name   string
+	Usage  string
+	Value  bool
+	Hidden bool
+}
+
+func (f BoolFlag) MainName() string {
+	return f.Name
+}
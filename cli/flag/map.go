@@ -0,0 +1,54 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flag
+
+// KeyValue is a single key/value pair parsed from a "--flag key=value" occurrence.
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// MapFlag is a Flag that accumulates repeated "--flag key=value" occurrences into a map, matching the shape of
+// options like docker's "--label" or "--build-arg". Each occurrence of the flag (such as "--label key=value")
+// records Value[Key] = Value. "--flag key=" records an empty string as the value for key (distinct from the key
+// being absent); only the first "=" in the argument is used as the separator, so "--flag key=a=b" records the value
+// "a=b" for key. If KeyValidator is set, it is invoked with the parsed key before the entry is recorded, and a
+// non-nil error aborts parsing with that error. Later occurrences of the same key overwrite earlier ones.
+type MapFlag struct {
+	Name         string
+	Usage        string
+	Value        map[string]string
+	KeepDefaults bool
+	KeyValidator func(key string) error
+}
+
+func (f MapFlag) MainName() string {
+	return f.Name
+}
+
+// KeyValueSlice is a Flag that accumulates repeated "--flag key=value" occurrences into an ordered slice of
+// KeyValue pairs, preserving both the order and any duplicate keys the user supplied (unlike MapFlag, which
+// collapses duplicate keys). KeyValidator and the "key=" / "key=a=b" parsing rules are the same as for MapFlag.
+type KeyValueSlice struct {
+	Name         string
+	Usage        string
+	Value        []KeyValue
+	KeepDefaults bool
+	KeyValidator func(key string) error
+}
+
+func (f KeyValueSlice) MainName() string {
+	return f.Name
+}
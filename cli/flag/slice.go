@@ -0,0 +1,74 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flag
+
+import "time"
+
+// StringSlice is a Flag whose value is built up by collecting every occurrence of the flag on the command line.
+//
+// If KeepDefaults is false (the default), the first time the user supplies the flag the values in Value are
+// discarded and replaced with the user-supplied values; every subsequent occurrence of the flag appends to that
+// user-supplied list. This means that, by default, a user-provided value replaces rather than augments the
+// configured defaults. If KeepDefaults is true, Value is never discarded: user-supplied values are always appended
+// to it.
+type StringSlice struct {
+	Name         string
+	Usage        string
+	Value        []string
+	KeepDefaults bool
+}
+
+func (f StringSlice) MainName() string {
+	return f.Name
+}
+
+// IntSlice is a Flag whose value is built up by collecting every occurrence of the flag on the command line, each
+// parsed as an int. KeepDefaults has the same meaning as it does for StringSlice.
+type IntSlice struct {
+	Name         string
+	Usage        string
+	Value        []int
+	KeepDefaults bool
+}
+
+func (f IntSlice) MainName() string {
+	return f.Name
+}
+
+// Float64Slice is a Flag whose value is built up by collecting every occurrence of the flag on the command line,
+// each parsed as a float64. KeepDefaults has the same meaning as it does for StringSlice.
+type Float64Slice struct {
+	Name         string
+	Usage        string
+	Value        []float64
+	KeepDefaults bool
+}
+
+func (f Float64Slice) MainName() string {
+	return f.Name
+}
+
+// DurationSlice is a Flag whose value is built up by collecting every occurrence of the flag on the command line,
+// each parsed with time.ParseDuration. KeepDefaults has the same meaning as it does for StringSlice.
+type DurationSlice struct {
+	Name         string
+	Usage        string
+	Value        []time.Duration
+	KeepDefaults bool
+}
+
+func (f DurationSlice) MainName() string {
+	return f.Name
+}
@@ -0,0 +1,38 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flag
+
+// StringFlag is a Flag that accepts a single string value. If the flag is not provided by the user, its value is
+// Value.
+type StringFlag struct {
+	Name  string
+	Usage string
+	Value string
+}
+
+func (f StringFlag) MainName() string {
+	return f.Name
+}
+
+// StringParam is a Flag that is populated from a positional (non "--flag") command-line argument rather than from a
+// named flag.
+type StringParam struct {
+	Name  string
+	Usage string
+}
+
+func (f StringParam) MainName() string {
+	return f.Name
+}
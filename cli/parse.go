@@ -0,0 +1,280 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/palantir/pkg/cli/flag"
+)
+
+// parseState accumulates the flag values parsed so far for a single App or Command invocation.
+type parseState struct {
+	values map[string]interface{}
+	// userProvided tracks, per flag name, whether the user has supplied at least one occurrence of the flag on the
+	// command line during this parse. It is used to determine whether a slice flag's defaults should be cleared
+	// (see appendStringSlice and friends).
+	userProvided map[string]bool
+}
+
+// parseFlags parses args (which does not include the program or command name) against flags, returning the
+// resulting flag values keyed by flag name.
+func parseFlags(flags []flag.Flag, args []string) (map[string]interface{}, error) {
+	state := &parseState{
+		values:       map[string]interface{}{},
+		userProvided: map[string]bool{},
+	}
+	for _, f := range flags {
+		initDefault(state, f)
+	}
+
+	var params []flag.StringParam
+	for _, f := range flags {
+		if p, ok := f.(flag.StringParam); ok {
+			params = append(params, p)
+		}
+	}
+	paramIdx := 0
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if !isFlagArg(arg) {
+			if paramIdx < len(params) {
+				state.values[params[paramIdx].Name] = arg
+				paramIdx++
+				continue
+			}
+			if err := appendToFirstSlice(state, flags, arg); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		f, value, hasValue := resolveFlagArg(flags, arg)
+		if f == nil {
+			return nil, fmt.Errorf("Unknown flag %v", arg)
+		}
+		name := f.MainName()
+
+		if boolFlag, ok := f.(flag.BoolFlag); ok {
+			if !hasValue {
+				state.values[boolFlag.Name] = true
+				continue
+			}
+			if value == "" {
+				return nil, fmt.Errorf("Missing value for flag --%v", name)
+			}
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("--%v: %v", name, err)
+			}
+			state.values[boolFlag.Name] = parsed
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("Missing value for flag --%v", name)
+			}
+			i++
+			value = args[i]
+		} else if value == "" {
+			return nil, fmt.Errorf("Missing value for flag --%v", name)
+		}
+
+		if err := setValue(state, f, name, value); err != nil {
+			return nil, err
+		}
+	}
+	return state.values, nil
+}
+
+// initDefault populates state.values with the default value for f.
+func initDefault(state *parseState, f flag.Flag) {
+	switch ff := f.(type) {
+	case flag.StringFlag:
+		state.values[ff.Name] = ff.Value
+	case flag.BoolFlag:
+		state.values[ff.Name] = ff.Value
+	case flag.StringParam:
+		state.values[ff.Name] = ""
+	case flag.StringSlice:
+		state.values[ff.Name] = append([]string{}, ff.Value...)
+	case flag.IntSlice:
+		state.values[ff.Name] = append([]int{}, ff.Value...)
+	case flag.Float64Slice:
+		state.values[ff.Name] = append([]float64{}, ff.Value...)
+	case flag.DurationSlice:
+		state.values[ff.Name] = append([]time.Duration{}, ff.Value...)
+	case flag.MapFlag:
+		values := make(map[string]string, len(ff.Value))
+		for k, v := range ff.Value {
+			values[k] = v
+		}
+		state.values[ff.Name] = values
+	case flag.KeyValueSlice:
+		state.values[ff.Name] = append([]flag.KeyValue{}, ff.Value...)
+	}
+}
+
+// setValue parses raw and records it as the value for f (which must not be a flag.BoolFlag; those are handled
+// directly in parseFlags).
+func setValue(state *parseState, f flag.Flag, name, raw string) error {
+	switch ff := f.(type) {
+	case flag.StringFlag:
+		state.values[ff.Name] = raw
+	case flag.StringSlice:
+		state.appendStringSlice(ff.Name, raw, ff.KeepDefaults)
+	case flag.IntSlice:
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("--%v: %v", name, err)
+		}
+		state.appendIntSlice(ff.Name, parsed, ff.KeepDefaults)
+	case flag.Float64Slice:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("--%v: %v", name, err)
+		}
+		state.appendFloat64Slice(ff.Name, parsed, ff.KeepDefaults)
+	case flag.DurationSlice:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("--%v: %v", name, err)
+		}
+		state.appendDurationSlice(ff.Name, parsed, ff.KeepDefaults)
+	case flag.MapFlag:
+		key, val := splitKeyValue(raw)
+		if err := validateKey(ff.KeyValidator, key); err != nil {
+			return fmt.Errorf("--%v: %v", name, err)
+		}
+		state.setMapEntry(ff.Name, key, val, ff.KeepDefaults)
+	case flag.KeyValueSlice:
+		key, val := splitKeyValue(raw)
+		if err := validateKey(ff.KeyValidator, key); err != nil {
+			return fmt.Errorf("--%v: %v", name, err)
+		}
+		state.appendKeyValueSlice(ff.Name, flag.KeyValue{Key: key, Value: val}, ff.KeepDefaults)
+	default:
+		return fmt.Errorf("unsupported flag type for --%v: %T", name, f)
+	}
+	return nil
+}
+
+// splitKeyValue splits a "key=value" argument on its first "=", matching the flag-parsing rule that only the first
+// "=" in an argument is treated as a separator. A raw value with no "=" is treated as a key with an empty value.
+func splitKeyValue(raw string) (key string, value string) {
+	if idx := strings.Index(raw, "="); idx != -1 {
+		return raw[:idx], raw[idx+1:]
+	}
+	return raw, ""
+}
+
+func validateKey(validator func(string) error, key string) error {
+	if validator == nil {
+		return nil
+	}
+	return validator(key)
+}
+
+// appendToFirstSlice appends value to the first flag.StringSlice declared in flags (other slice types are not
+// considered). It is used for positional arguments
+// that do not correspond to a declared flag.StringParam.
+func appendToFirstSlice(state *parseState, flags []flag.Flag, value string) error {
+	for _, f := range flags {
+		if ff, ok := f.(flag.StringSlice); ok {
+			state.appendStringSlice(ff.Name, value, ff.KeepDefaults)
+			return nil
+		}
+	}
+	return fmt.Errorf("Unexpected argument: %v", value)
+}
+
+// appendStringSlice appends value to the named string-slice flag's value. If this is the first time the user has
+// supplied the flag during this parse and keepDefaults is false, the flag's default values are cleared before value
+// is appended.
+func (s *parseState) appendStringSlice(name, value string, keepDefaults bool) {
+	if !keepDefaults && !s.userProvided[name] {
+		s.values[name] = []string{}
+	}
+	s.userProvided[name] = true
+	cur, _ := s.values[name].([]string)
+	s.values[name] = append(cur, value)
+}
+
+// appendIntSlice appends value to the named int-slice flag's value, applying the same first-occurrence
+// default-clearing behavior as appendStringSlice.
+func (s *parseState) appendIntSlice(name string, value int, keepDefaults bool) {
+	if !keepDefaults && !s.userProvided[name] {
+		s.values[name] = []int{}
+	}
+	s.userProvided[name] = true
+	cur, _ := s.values[name].([]int)
+	s.values[name] = append(cur, value)
+}
+
+// appendFloat64Slice appends value to the named float64-slice flag's value, applying the same first-occurrence
+// default-clearing behavior as appendStringSlice.
+func (s *parseState) appendFloat64Slice(name string, value float64, keepDefaults bool) {
+	if !keepDefaults && !s.userProvided[name] {
+		s.values[name] = []float64{}
+	}
+	s.userProvided[name] = true
+	cur, _ := s.values[name].([]float64)
+	s.values[name] = append(cur, value)
+}
+
+// appendDurationSlice appends value to the named duration-slice flag's value, applying the same first-occurrence
+// default-clearing behavior as appendStringSlice.
+func (s *parseState) appendDurationSlice(name string, value time.Duration, keepDefaults bool) {
+	if !keepDefaults && !s.userProvided[name] {
+		s.values[name] = []time.Duration{}
+	}
+	s.userProvided[name] = true
+	cur, _ := s.values[name].([]time.Duration)
+	s.values[name] = append(cur, value)
+}
+
+// setMapEntry records value under key in the named MapFlag's value. If this is the first time the user has
+// supplied the flag during this parse and keepDefaults is false, the flag's defaults are cleared first. A duplicate
+// key within the same parse overwrites the earlier entry.
+func (s *parseState) setMapEntry(name, key, value string, keepDefaults bool) {
+	if !keepDefaults && !s.userProvided[name] {
+		s.values[name] = map[string]string{}
+	}
+	s.userProvided[name] = true
+	cur, _ := s.values[name].(map[string]string)
+	if cur == nil {
+		cur = map[string]string{}
+	}
+	cur[key] = value
+	s.values[name] = cur
+}
+
+// appendKeyValueSlice appends kv to the named KeyValueSlice flag's value, applying the same first-occurrence
+// default-clearing behavior as appendStringSlice. Unlike setMapEntry, duplicate keys are preserved rather than
+// collapsed.
+func (s *parseState) appendKeyValueSlice(name string, kv flag.KeyValue, keepDefaults bool) {
+	if !keepDefaults && !s.userProvided[name] {
+		s.values[name] = []flag.KeyValue{}
+	}
+	s.userProvided[name] = true
+	cur, _ := s.values[name].([]flag.KeyValue)
+	s.values[name] = append(cur, kv)
+}
@@ -234,6 +234,189 @@ func TestParseFlags(t *testing.T) {
 			},
 			expectedError: `--bool: strconv.ParseBool: parsing "NOT_VALID": invalid syntax`,
 		},
+		// string slice flag with defaults discards the defaults on the first user-supplied value and collects
+		// the rest
+		{
+			flags: []flag.Flag{
+				flag.StringSlice{
+					Name:  "foo",
+					Value: []string{"default-1", "default-2"},
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--foo=bar",
+				"--foo=baz",
+			},
+			expectedOutput: "foo: [bar baz]",
+		},
+		// string slice flag with KeepDefaults set to true preserves the defaults and appends user-supplied values
+		{
+			flags: []flag.Flag{
+				flag.StringSlice{
+					Name:         "foo",
+					Value:        []string{"default-1", "default-2"},
+					KeepDefaults: true,
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--foo=bar",
+				"--foo=baz",
+			},
+			expectedOutput: "foo: [default-1 default-2 bar baz]",
+		},
+		// string slice flag with defaults and no user-supplied values retains the defaults
+		{
+			flags: []flag.Flag{
+				flag.StringSlice{
+					Name:  "foo",
+					Value: []string{"default-1", "default-2"},
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+			},
+			expectedOutput: "foo: [default-1 default-2]",
+		},
+		// int slice flag collects user-supplied values, discarding defaults
+		{
+			flags: []flag.Flag{
+				flag.IntSlice{
+					Name:  "foo",
+					Value: []int{1, 2},
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--foo=3",
+				"--foo=4",
+			},
+			expectedOutput: "foo: [3 4]",
+		},
+		// float64 slice flag collects user-supplied values, discarding defaults
+		{
+			flags: []flag.Flag{
+				flag.Float64Slice{
+					Name:  "foo",
+					Value: []float64{1.5},
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--foo=2.5",
+				"--foo=3.5",
+			},
+			expectedOutput: "foo: [2.5 3.5]",
+		},
+		// duration slice flag without defaults collects user-supplied values
+		{
+			flags: []flag.Flag{
+				flag.DurationSlice{
+					Name: "foo",
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--foo=1s",
+				"--foo=2m",
+			},
+			expectedOutput: "foo: [1s 2m0s]",
+		},
+		// map flag collects repeated key=value occurrences into a map, discarding defaults
+		{
+			flags: []flag.Flag{
+				flag.MapFlag{
+					Name:  "label",
+					Value: map[string]string{"default": "value"},
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--label",
+				"env=prod",
+				"--label",
+				"owner=",
+			},
+			expectedOutput: `label: map[env:prod owner:]`,
+		},
+		// map flag splits only on the first '=', matching the existing "--name=foo=bar" parsing rule
+		{
+			flags: []flag.Flag{
+				flag.MapFlag{
+					Name: "label",
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--label",
+				"url=http://foo=bar",
+			},
+			expectedOutput: `label: map[url:http://foo=bar]`,
+		},
+		// map flag with KeepDefaults true keeps defaults and lets user values overwrite matching keys
+		{
+			flags: []flag.Flag{
+				flag.MapFlag{
+					Name:         "label",
+					Value:        map[string]string{"env": "dev"},
+					KeepDefaults: true,
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--label",
+				"env=prod",
+			},
+			expectedOutput: `label: map[env:prod]`,
+		},
+		// key-value slice flag preserves order and duplicate keys
+		{
+			flags: []flag.Flag{
+				flag.KeyValueSlice{
+					Name: "env",
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--env",
+				"FOO=1",
+				"--env",
+				"FOO=2",
+			},
+			expectedOutput: "env: [{FOO 1} {FOO 2}]",
+		},
+		// map flag rejects keys that fail KeyValidator
+		{
+			flags: []flag.Flag{
+				flag.MapFlag{
+					Name: "label",
+					KeyValidator: func(key string) error {
+						if key == "" {
+							return fmt.Errorf("key must not be empty")
+						}
+						return nil
+					},
+				},
+			},
+			args: []string{
+				"./test",
+				"test-cmd",
+				"--label",
+				"=value",
+			},
+			expectedError: "--label: key must not be empty",
+		},
 	}
 
 	for i, currCase := range cases {
@@ -287,6 +470,16 @@ func printFlags(w io.Writer, ctx cli.Context, flags []flag.Flag) {
 			fmt.Fprintf(w, "%v: %v", currFlag.Name, ctx.Bool(currFlag.Name))
 		case flag.StringSlice:
 			fmt.Fprintf(w, "%v: %v", currFlag.Name, ctx.Slice(currFlag.Name))
+		case flag.IntSlice:
+			fmt.Fprintf(w, "%v: %v", currFlag.Name, ctx.IntSlice(currFlag.Name))
+		case flag.Float64Slice:
+			fmt.Fprintf(w, "%v: %v", currFlag.Name, ctx.Float64Slice(currFlag.Name))
+		case flag.DurationSlice:
+			fmt.Fprintf(w, "%v: %v", currFlag.Name, ctx.DurationSlice(currFlag.Name))
+		case flag.MapFlag:
+			fmt.Fprintf(w, "%v: %v", currFlag.Name, ctx.StringMap(currFlag.Name))
+		case flag.KeyValueSlice:
+			fmt.Fprintf(w, "%v: %v", currFlag.Name, ctx.KeyValueSlice(currFlag.Name))
 		default:
 			panic(fmt.Sprintf("unsupported type: %v", currFlag))
 		}
@@ -0,0 +1,185 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DirMatcher is an optional interface that a Matcher can implement to report a different result depending on
+// whether the candidate path is a directory. For example, a Gitignore pattern ending in "/" only matches
+// directories. Matcher implementations that are agnostic to this distinction (like all of the other matchers in
+// this package) do not need to implement it; existing Matcher implementations remain source-compatible.
+type DirMatcher interface {
+	Matcher
+	// MatchIsDir behaves like Match, but additionally considers whether relPath refers to a directory.
+	MatchIsDir(relPath string, isDir bool) bool
+}
+
+// Gitignore returns a Matcher (which also implements DirMatcher) that matches paths using gitignore semantics
+// applied to the provided patterns, in order:
+//   - "**" matches zero or more path segments (for example, "a/**/b" matches "a/b", "a/x/b", and "a/x/y/b").
+//   - a pattern with a trailing "/" only matches directories; use MatchIsDir (or Match, which treats every path as
+//     a non-directory) to take this into account.
+//   - a pattern with a leading "/", or with a "/" anywhere other than at the end, is anchored to the root (it is
+//     matched against the whole relative path rather than against every path segment); all other patterns may
+//     match starting at any path segment.
+//   - a pattern with a leading "!" negates a match made by an earlier pattern.
+//   - later patterns override earlier ones: the match (or non-match) produced by the last pattern that applies to
+//     a given path is the final decision.
+//
+// As with Path, a match of a prefix of a path's segments is also considered a match of the full path (for example,
+// the pattern "build" matches "build/output.txt").
+func Gitignore(patterns ...string) Matcher {
+	var compiled []compiledGitignorePattern
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+		compiled = append(compiled, compileGitignorePattern(p))
+	}
+	return gitignoreMatcher(compiled)
+}
+
+type compiledGitignorePattern struct {
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	segments []string
+}
+
+func compileGitignorePattern(pattern string) compiledGitignorePattern {
+	var c compiledGitignorePattern
+
+	if strings.HasPrefix(pattern, "!") {
+		c.negate = true
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		c.dirOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if strings.HasPrefix(pattern, "/") {
+		c.anchored = true
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+	// a pattern containing a "/" anywhere other than at the end is anchored to the root even without a leading "/"
+	if !c.anchored && strings.Contains(pattern, "/") {
+		c.anchored = true
+	}
+
+	c.segments = strings.Split(pattern, "/")
+	return c
+}
+
+type gitignoreMatcher []compiledGitignorePattern
+
+func (m gitignoreMatcher) Match(relPath string) bool {
+	return m.match(relPath, false)
+}
+
+func (m gitignoreMatcher) MatchIsDir(relPath string, isDir bool) bool {
+	return m.match(relPath, isDir)
+}
+
+func (m gitignoreMatcher) match(relPath string, isDir bool) bool {
+	if path.IsAbs(relPath) {
+		return false
+	}
+	pathSegments := strings.Split(path.Clean(relPath), "/")
+
+	matched := false
+	for _, p := range []compiledGitignorePattern(m) {
+		if p.matches(pathSegments, isDir) {
+			matched = !p.negate
+		}
+	}
+	return matched
+}
+
+// matches returns true if the pattern matches path (taking dirOnly/isDir into account).
+func (p compiledGitignorePattern) matches(path []string, isDir bool) bool {
+	loose, exact := matchSegmentsAnyStart(p.segments, path, p.anchored)
+	if !loose {
+		return false
+	}
+	if !p.dirOnly {
+		return true
+	}
+	if !exact || isDir {
+		// either the pattern matched a proper subpath (prefix) of path, which is necessarily a directory, or path
+		// itself is a directory.
+		return true
+	}
+	// path is a non-directory matched exactly, but it is still a match if the pattern matches one of its parent
+	// directories (for example, "build/" against "build/x/build").
+	if len(path) < 2 {
+		return false
+	}
+	parentMatch, _ := matchSegmentsAnyStart(p.segments, path[:len(path)-1], p.anchored)
+	return parentMatch
+}
+
+// matchSegmentsAnyStart reports whether pattern matches path as either a "loose" match (pattern consumed,
+// regardless of whether path has leftover segments) or an "exact" match (pattern and path both fully consumed). If
+// anchored is false, pattern may match starting at any segment of path, not just the first.
+func matchSegmentsAnyStart(pattern, path []string, anchored bool) (loose bool, exact bool) {
+	if anchored {
+		return matchSegments(pattern, path, false), matchSegments(pattern, path, true)
+	}
+	for i := 0; i <= len(path); i++ {
+		if matchSegments(pattern, path[i:], false) {
+			loose = true
+		}
+		if matchSegments(pattern, path[i:], true) {
+			exact = true
+		}
+	}
+	return loose, exact
+}
+
+// matchSegments reports whether pattern matches a prefix of path, consuming pattern entirely. If requireExact is
+// true, path must also be consumed entirely (no leftover segments).
+func matchSegments(pattern, path []string, requireExact bool) bool {
+	if len(pattern) == 0 {
+		return !requireExact || len(path) == 0
+	}
+	if pattern[0] == "**" {
+		if len(pattern) == 1 {
+			return true
+		}
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:], requireExact) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	match, err := filepath.Match(pattern[0], path[0])
+	if err != nil {
+		// only possible error is bad pattern
+		panic(fmt.Sprintf("filepath: Match(%q): %v", pattern[0], err))
+	}
+	if !match {
+		return false
+	}
+	return matchSegments(pattern[1:], path[1:], requireExact)
+}
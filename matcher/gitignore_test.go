@@ -0,0 +1,111 @@
+// Copyright 2016 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcher_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/matcher"
+)
+
+func TestGitignoreDoubleStar(t *testing.T) {
+	cases := []struct {
+		patterns []string
+		path     string
+		want     bool
+	}{
+		// "**/foo" matches "foo" at any depth
+		{[]string{"**/foo"}, "foo", true},
+		{[]string{"**/foo"}, "a/b/foo", true},
+		{[]string{"**/foo"}, "a/b/bar", false},
+		// "foo/**" matches everything under "foo"
+		{[]string{"foo/**"}, "foo/bar", true},
+		{[]string{"foo/**"}, "foo/bar/baz", true},
+		{[]string{"foo/**"}, "bar/foo", false},
+		// "a/**/b" matches "a/b" and any number of intermediate segments
+		{[]string{"a/**/b"}, "a/b", true},
+		{[]string{"a/**/b"}, "a/x/b", true},
+		{[]string{"a/**/b"}, "a/x/y/b", true},
+		{[]string{"a/**/b"}, "a/x/y/c", false},
+	}
+	for i, c := range cases {
+		m := matcher.Gitignore(c.patterns...)
+		assert.Equal(t, c.want, m.Match(c.path), "Case %d: %v matching %q", i, c.patterns, c.path)
+	}
+}
+
+func TestGitignoreAnchoring(t *testing.T) {
+	cases := []struct {
+		patterns []string
+		path     string
+		want     bool
+	}{
+		// unanchored pattern matches the name at any depth
+		{[]string{"build"}, "build", true},
+		{[]string{"build"}, "a/build", true},
+		// leading "/" anchors the pattern to the root
+		{[]string{"/build"}, "build", true},
+		{[]string{"/build"}, "a/build", false},
+		// a "/" anywhere other than at the end anchors the pattern even without a leading "/"
+		{[]string{"a/build"}, "a/build", true},
+		{[]string{"a/build"}, "x/a/build", false},
+		// a match of a path's prefix also matches its subpaths
+		{[]string{"build"}, "build/output.txt", true},
+	}
+	for i, c := range cases {
+		m := matcher.Gitignore(c.patterns...)
+		assert.Equal(t, c.want, m.Match(c.path), "Case %d: %v matching %q", i, c.patterns, c.path)
+	}
+}
+
+func TestGitignoreNegation(t *testing.T) {
+	cases := []struct {
+		patterns []string
+		path     string
+		want     bool
+	}{
+		// a later negation overrides an earlier match
+		{[]string{"build/**", "!build/keep/**"}, "build/output.txt", true},
+		{[]string{"build/**", "!build/keep/**"}, "build/keep/file.txt", false},
+		// a later pattern re-matching a previously-negated path wins again (final decision wins)
+		{[]string{"build/**", "!build/keep/**", "build/keep/ignored.txt"}, "build/keep/ignored.txt", true},
+		{[]string{"build/**", "!build/keep/**", "build/keep/ignored.txt"}, "build/keep/file.txt", false},
+	}
+	for i, c := range cases {
+		m := matcher.Gitignore(c.patterns...)
+		assert.Equal(t, c.want, m.Match(c.path), "Case %d: %v matching %q", i, c.patterns, c.path)
+	}
+}
+
+func TestGitignoreDirOnly(t *testing.T) {
+	m := matcher.Gitignore("build/")
+	dirMatcher, ok := m.(matcher.DirMatcher)
+	if !assert.True(t, ok, "Gitignore matcher should implement DirMatcher") {
+		return
+	}
+
+	// a dir-only pattern matches a path that is itself a directory
+	assert.True(t, dirMatcher.MatchIsDir("build", true))
+	// a dir-only pattern does not match a path that is itself a file
+	assert.False(t, dirMatcher.MatchIsDir("build", false))
+	// a dir-only pattern still matches paths nested inside the matched directory regardless of their own type
+	assert.True(t, dirMatcher.MatchIsDir("build/output.txt", false))
+
+	// Match always treats the candidate path as a non-directory
+	assert.False(t, m.Match("build"))
+	assert.True(t, m.Match("build/output.txt"))
+}